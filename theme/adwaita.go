@@ -0,0 +1,65 @@
+package theme
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+//go:generate go run ./adwaita_colors_generator.go
+
+// Adwaita is a fyne.Theme that mimics the GNOME Adwaita color palette.
+// Its color scheme is generated from the official named-colors
+// documentation by adwaita_colors_generator.go; run `go generate
+// ./theme/...` to refresh it. Text emphasis (see TextStyle) is
+// hand-curated in adwaita_styles.go, since that documentation carries
+// no emphasis markup to generate from. Fonts, icons and sizes are
+// inherited from Fyne's default theme.
+type Adwaita struct {
+}
+
+// NewAdwaitaTheme creates a fyne.Theme using the Adwaita color palette.
+func NewAdwaitaTheme() fyne.Theme {
+	return &Adwaita{}
+}
+
+// Color looks up a color for the given name and variant in the
+// generated Adwaita scheme, falling back to Fyne's default theme for
+// any name Adwaita does not override.
+func (a *Adwaita) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	scheme := adwaitaLightScheme
+	if variant == theme.VariantDark {
+		scheme = adwaitaDarkScheme
+	}
+
+	if c, ok := scheme[name]; ok {
+		return c
+	}
+	return theme.DefaultTheme().Color(name, variant)
+}
+
+// Font returns the default theme's font, Adwaita does not override fonts.
+func (a *Adwaita) Font(style fyne.TextStyle) fyne.Font {
+	return theme.DefaultTheme().Font(style)
+}
+
+// Icon returns the default theme's icon, Adwaita does not override icons.
+func (a *Adwaita) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+// Size returns the default theme's size, Adwaita does not override sizes.
+func (a *Adwaita) Size(name fyne.ThemeSizeName) float32 {
+	return theme.DefaultTheme().Size(name)
+}
+
+// TextStyle returns the text emphasis Adwaita associates with the given
+// color slot, e.g. ColorNameWarning and ColorNameError render in bold,
+// so that widgets consulting the theme (hyperlinks, warnings, success
+// labels) pick up the semantically correct emphasis automatically
+// instead of hardcoding it themselves.
+func (a *Adwaita) TextStyle(name fyne.ThemeColorName) fyne.TextStyle {
+	style := adwaitaStyles[name]
+	return fyne.TextStyle{Bold: style.Bold, Italic: style.Italic}
+}
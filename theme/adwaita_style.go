@@ -0,0 +1,12 @@
+package theme
+
+// AdwaitaStyle describes the text emphasis associated with a theme
+// color slot, e.g. warnings and errors rendered in bold. Values are
+// hand-curated in adwaita_styles.go, since Adwaita's named-colors
+// documentation does not itself describe emphasis, and are consumed by
+// Adwaita.TextStyle. Its fields mirror fyne.TextStyle's bold/italic,
+// the only emphasis fyne.TextStyle can carry.
+type AdwaitaStyle struct {
+	Bold   bool
+	Italic bool
+}
@@ -0,0 +1,35 @@
+package theme
+
+// This file is hand-maintained, unlike adwaita_colors.go. Adwaita's
+// named-colors documentation is a plain color table with no emphasis
+// markup to scrape, so the text styling below is curated by hand to
+// match how GNOME applications typically render these slots (e.g.
+// warnings and errors in bold) and is not touched by
+// `go generate ./theme/...`.
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+var adwaitaStyles = map[fyne.ThemeColorName]AdwaitaStyle{
+	theme.ColorNameBackground:        {},
+	theme.ColorNameForeground:        {},
+	theme.ColorNameMenuBackground:    {},
+	theme.ColorNameSelection:         {},
+	theme.ColorNameOverlayBackground: {},
+	theme.ColorNamePrimary:           {},
+	theme.ColorNameInputBackground:   {},
+	theme.ColorNameButton:            {},
+	theme.ColorNameSuccess:           {Bold: true},
+	theme.ColorNameWarning:           {Bold: true},
+	theme.ColorNameError:             {Bold: true},
+	theme.ColorRed:                   {},
+	theme.ColorOrange:                {},
+	theme.ColorYellow:                {},
+	theme.ColorGreen:                 {},
+	theme.ColorBlue:                  {},
+	theme.ColorPurple:                {},
+	theme.ColorBrown:                 {},
+	theme.ColorGray:                  {},
+}
@@ -5,12 +5,14 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/nfnt/resize"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
@@ -20,24 +22,103 @@ import (
 
 const tileSize = 256
 
-// Map widget renders an interactive map using OpenStreetMap tile data.
+// effectFrameInterval caps the redraw rate driven by EffectLayers to
+// roughly 30 FPS, independent of the canvas' own refresh rate.
+const effectFrameInterval = time.Second / 30
+
+// Map widget renders an interactive map using tile data from a
+// pluggable TileSource, OpenStreetMap by default.
 type Map struct {
 	widget.BaseWidget
 
-	pixels     *image.NRGBA
-	w, h       int
-	zoom, x, y int
+	pixels *image.NRGBA
+	w, h   int
+	zoom   int
+	x, y   float64 // fractional world tile offset from the centre of the map
+
+	source  TileSource
+	markers []*Marker
+
+	effects         []EffectLayer
+	effectStart     time.Time
+	lastEffectFrame time.Time
+	anim            *fyne.Animation
 
 	cl *http.Client
 }
 
 // NewMap creates a new instance of the map widget.
 func NewMap() *Map {
-	m := &Map{cl: &http.Client{}}
+	m := &Map{cl: &http.Client{}, source: OSMTileSource}
 	m.ExtendBaseWidget(m)
 	return m
 }
 
+// AddEffectLayer registers a procedural overlay that is composited on
+// top of the tile raster every animation frame, and starts the ~30 FPS
+// animation driving it if this is the first layer added.
+func (m *Map) AddEffectLayer(layer EffectLayer) {
+	if len(m.effects) == 0 {
+		m.effectStart = time.Now()
+		m.startEffectAnimation()
+	}
+	m.effects = append(m.effects, layer)
+}
+
+// RemoveEffectLayer removes a layer previously added with
+// AddEffectLayer, stopping the animation once no layers remain.
+func (m *Map) RemoveEffectLayer(layer EffectLayer) {
+	for i, l := range m.effects {
+		if l == layer {
+			m.effects = append(m.effects[:i], m.effects[i+1:]...)
+			break
+		}
+	}
+	if len(m.effects) == 0 {
+		m.stopEffectAnimation()
+	}
+}
+
+// ClearEffectLayers removes all registered effect layers.
+func (m *Map) ClearEffectLayers() {
+	m.effects = nil
+	m.stopEffectAnimation()
+}
+
+func (m *Map) startEffectAnimation() {
+	if m.anim != nil {
+		return
+	}
+
+	m.anim = fyne.NewAnimation(time.Hour, func(_ float32) {
+		if time.Since(m.lastEffectFrame) < effectFrameInterval {
+			return
+		}
+		m.lastEffectFrame = time.Now()
+		m.Refresh()
+	})
+	m.anim.RepeatCount = fyne.AnimationRepeatForever
+	m.anim.Curve = fyne.AnimationLinear
+	m.anim.Start()
+}
+
+func (m *Map) stopEffectAnimation() {
+	if m.anim == nil {
+		return
+	}
+	m.anim.Stop()
+	m.anim = nil
+}
+
+// SetTileSource changes the tile provider used to render the map.
+func (m *Map) SetTileSource(source TileSource) {
+	m.source = source
+	if m.zoom > source.MaxZoom() {
+		m.zoom = source.MaxZoom()
+	}
+	m.Refresh()
+}
+
 // MinSize returns the smallest possible size for a widget.
 // For our map this is a constant size representing a single tile on a device with
 // the highest known DPI (4x).
@@ -45,15 +126,203 @@ func (m *Map) MinSize() fyne.Size {
 	return fyne.NewSize(64, 64)
 }
 
+// SetCenter centers the map on the given geographic coordinate, expressed
+// as latitude and longitude in degrees.
+func (m *Map) SetCenter(lat, lon float64) {
+	tx, ty := lonLatToTileXY(lon, lat, m.zoom)
+	m.setCenterTile(tx, ty)
+	m.Refresh()
+}
+
+// Center returns the geographic coordinate, as latitude and longitude in
+// degrees, at the centre of the map.
+func (m *Map) Center() (lat, lon float64) {
+	mx, my := m.centerTile()
+	return tileXYToLonLat(mx, my, m.zoom)
+}
+
+// SetZoom sets the zoom level of the map, keeping the current centre
+// coordinate fixed.
+func (m *Map) SetZoom(zoom int) {
+	if zoom < 0 {
+		zoom = 0
+	} else if zoom > m.source.MaxZoom() {
+		zoom = m.source.MaxZoom()
+	}
+
+	lat, lon := m.Center()
+	m.zoom = zoom
+	m.SetCenter(lat, lon)
+}
+
+// centerTile returns the fractional world tile coordinate at the centre
+// of the map's current viewport.
+func (m *Map) centerTile() (mx, my float64) {
+	count := 1 << m.zoom
+	return m.x + float64(count)/2, m.y + float64(count)/2
+}
+
+// setCenterTile moves the map so that the given fractional world tile
+// coordinate sits at the centre of the viewport.
+func (m *Map) setCenterTile(mx, my float64) {
+	count := 1 << m.zoom
+	m.x = mx - float64(count)/2
+	m.y = my - float64(count)/2
+}
+
+// tileGeometry returns the on-screen tile size in pixels, scaled for the
+// canvas' pixel density, along with that scale factor.
+func (m *Map) tileGeometry() (size, scale int) {
+	size = m.source.TileSize()
+	scale = 1
+	if c := fyne.CurrentApp().Driver().CanvasForObject(m); c != nil {
+		scale = int(c.Scale())
+		if scale < 1 {
+			scale = 1
+		}
+		size *= scale
+	}
+	return size, scale
+}
+
+// viewportOrigin returns the pixel position, within a renderer of size
+// w x h, of the world tile coordinate at centerTile(), which is always
+// the centre of the viewport.
+func (m *Map) viewportOrigin(w, h int) (midX, midY int) {
+	return w / 2, h / 2
+}
+
+// latLonForPixel converts a pixel position within a renderer of size
+// w x h into a geographic coordinate. It is the inverse of
+// pixelForLatLon.
+func (m *Map) latLonForPixel(pos fyne.Position, w, h int) (lat, lon float64) {
+	tileSize, _ := m.tileGeometry()
+	midTileX, midTileY := m.viewportOrigin(w, h)
+	mx, my := m.centerTile()
+
+	tx := mx + float64(pos.X-float32(midTileX))/float64(tileSize)
+	ty := my + float64(pos.Y-float32(midTileY))/float64(tileSize)
+	return tileXYToLonLat(tx, ty, m.zoom)
+}
+
+// centerOnPixel moves the map so that the given geographic coordinate
+// projects to pos within a renderer of size w x h, without changing the
+// current zoom level.
+func (m *Map) centerOnPixel(lat, lon float64, pos fyne.Position, w, h int) {
+	tileSize, _ := m.tileGeometry()
+	midTileX, midTileY := m.viewportOrigin(w, h)
+
+	tx, ty := lonLatToTileXY(lon, lat, m.zoom)
+	mx := tx - float64(pos.X-float32(midTileX))/float64(tileSize)
+	my := ty - float64(pos.Y-float32(midTileY))/float64(tileSize)
+	m.setCenterTile(mx, my)
+}
+
+// Dragged moves the map by the drag delta, panning at sub-tile
+// resolution. It implements fyne.Draggable.
+func (m *Map) Dragged(ev *fyne.DragEvent) {
+	tileSize, _ := m.tileGeometry()
+	m.x -= float64(ev.Dragged.DX) / float64(tileSize)
+	m.y -= float64(ev.Dragged.DY) / float64(tileSize)
+	m.Refresh()
+}
+
+// DragEnd implements fyne.Draggable.
+func (m *Map) DragEnd() {
+}
+
+// Scrolled zooms the map in or out, keeping the point under the cursor
+// fixed on screen. It implements fyne.Scrollable.
+func (m *Map) Scrolled(ev *fyne.ScrollEvent) {
+	newZoom := m.zoom
+	if ev.Scrolled.DY > 0 {
+		newZoom++
+	} else if ev.Scrolled.DY < 0 {
+		newZoom--
+	}
+	if newZoom < 0 {
+		newZoom = 0
+	} else if newZoom > m.source.MaxZoom() {
+		newZoom = m.source.MaxZoom()
+	}
+	if newZoom == m.zoom {
+		return
+	}
+
+	size := m.Size()
+	w, h := int(size.Width), int(size.Height)
+	lat, lon := m.latLonForPixel(ev.Position, w, h)
+
+	m.zoom = newZoom
+	m.centerOnPixel(lat, lon, ev.Position, w, h)
+	m.Refresh()
+}
+
+// Tapped implements fyne.Tappable, so the map consumes single taps that
+// are not handled by an overlaid Marker.
+func (m *Map) Tapped(_ *fyne.PointEvent) {
+}
+
+// DoubleTapped zooms the map in, centring on the tapped location.
+func (m *Map) DoubleTapped(ev *fyne.PointEvent) {
+	if m.zoom >= m.source.MaxZoom() {
+		return
+	}
+
+	size := m.Size()
+	w, h := int(size.Width), int(size.Height)
+	lat, lon := m.latLonForPixel(ev.Position, w, h)
+
+	m.zoom++
+	m.centerOnPixel(lat, lon, ev.Position, w, h)
+	m.Refresh()
+}
+
+// Marker is a point of interest overlaid on a Map at a fixed geographic
+// location. It is drawn above the tile raster and stays pinned to its
+// coordinate across pans and zooms.
+type Marker struct {
+	Lat, Lon float64
+
+	Icon     fyne.Resource
+	Tooltip  string
+	OnTapped func()
+}
+
+// AddMarker adds a marker to the map and refreshes the overlay.
+func (m *Map) AddMarker(marker *Marker) {
+	m.markers = append(m.markers, marker)
+	m.Refresh()
+}
+
+// RemoveMarker removes a marker that was previously added with AddMarker.
+// It is a no-op if the marker is not present.
+func (m *Map) RemoveMarker(marker *Marker) {
+	for i, mk := range m.markers {
+		if mk == marker {
+			m.markers = append(m.markers[:i], m.markers[i+1:]...)
+			break
+		}
+	}
+	m.Refresh()
+}
+
+// ClearMarkers removes all markers from the map.
+func (m *Map) ClearMarkers() {
+	m.markers = nil
+	m.Refresh()
+}
+
 // CreateRenderer returns the renderer for this widget.
-// A map renderer is simply the map Raster with user interface elements overlaid.
+// A map renderer is the map Raster with the marker overlay and user
+// interface elements stacked on top.
 func (m *Map) CreateRenderer() fyne.WidgetRenderer {
 	license, _ := url.Parse("https://openstreetmap.org")
-	copyright := widget.NewHyperlink("OpenStreetMap", license)
+	copyright := widget.NewHyperlink(m.source.Attribution(), license)
 	copyright.Alignment = fyne.TextAlignTrailing
 	zoom := container.NewVBox(
 		newMapButton(theme.ZoomInIcon(), func() {
-			if m.zoom >= 19 {
+			if m.zoom >= m.source.MaxZoom() {
 				return
 			}
 			m.zoom++
@@ -90,54 +359,232 @@ func (m *Map) CreateRenderer() fyne.WidgetRenderer {
 		}), layout.NewSpacer())
 
 	overlay := container.NewBorder(nil, copyright, container.NewVBox(move), zoom)
+	markers := container.NewWithoutLayout()
 
-	c := container.NewMax(canvas.NewRaster(m.draw), overlay)
-	return widget.NewSimpleRenderer(c)
+	c := container.NewMax(canvas.NewRaster(m.draw), markers, overlay)
+	return &mapRenderer{m: m, copyright: copyright, markers: markers, objects: []fyne.CanvasObject{c},
+		icons: map[*Marker]*mapMarkerIcon{}}
 }
 
-func (m *Map) draw(w, h int) image.Image {
-	scale := 1
-	tileSize := tileSize
-	// TODO use retina tiles once OSM supports it in their server (text scaling issues)...
-	if c := fyne.CurrentApp().Driver().CanvasForObject(m); c != nil {
-		scale = int(c.Scale())
-		if scale < 1 {
-			scale = 1
+// mapRenderer lays out the tile raster, the marker overlay and the
+// pan/zoom button overlay, repositioning markers whenever the map's size,
+// centre or zoom changes.
+type mapRenderer struct {
+	m         *Map
+	copyright *widget.Hyperlink
+	markers   *fyne.Container
+	objects   []fyne.CanvasObject
+
+	// icons caches the marker icon widget for each live Marker across
+	// refreshes, so an EffectLayer's ~30 FPS redraws or an in-progress
+	// drag don't tear down and recreate the icon a user is hovering or
+	// has tapped.
+	icons map[*Marker]*mapMarkerIcon
+}
+
+func (r *mapRenderer) Destroy() {
+	for _, icon := range r.icons {
+		icon.MouseOut()
+	}
+}
+
+func (r *mapRenderer) Layout(size fyne.Size) {
+	for _, o := range r.objects {
+		o.Resize(size)
+	}
+	r.layoutMarkers(size)
+}
+
+func (r *mapRenderer) MinSize() fyne.Size {
+	return r.m.MinSize()
+}
+
+func (r *mapRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *mapRenderer) Refresh() {
+	r.copyright.SetText(r.m.source.Attribution())
+	r.layoutMarkers(r.m.Size())
+	canvas.Refresh(r.m)
+}
+
+// layoutMarkers repositions the marker overlay from m.markers, placing
+// each one at the pixel coordinate its lat/lon projects to and hiding any
+// that have panned out of view. Icon widgets are reused across calls,
+// keyed by their Marker, so a marker the user is hovering or has tapped
+// keeps its widget identity (and hover/tooltip state) across the ~30 FPS
+// refreshes an EffectLayer or a drag-pan can trigger; markers that are
+// no longer present, or have panned out of view, have their icon
+// discarded.
+func (r *mapRenderer) layoutMarkers(size fyne.Size) {
+	w, h := int(size.Width), int(size.Height)
+
+	live := make(map[*Marker]bool, len(r.m.markers))
+	objs := make([]fyne.CanvasObject, 0, len(r.m.markers))
+	for _, mk := range r.m.markers {
+		pos, visible := r.m.pixelForLatLon(mk.Lat, mk.Lon, w, h)
+		if !visible {
+			continue
+		}
+		live[mk] = true
+
+		icon, ok := r.icons[mk]
+		if !ok {
+			icon = newMapMarkerIcon(mk)
+			icon.Resize(icon.MinSize())
+			r.icons[mk] = icon
+		}
+		icon.Move(pos.Subtract(fyne.NewPos(icon.MinSize().Width/2, icon.MinSize().Height)))
+		objs = append(objs, icon)
+	}
+
+	for mk, icon := range r.icons {
+		if live[mk] {
+			continue
 		}
-		tileSize = tileSize * scale
+		icon.MouseOut()
+		delete(r.icons, mk)
+	}
+
+	r.markers.Objects = objs
+	r.markers.Refresh()
+}
+
+// pixelForLatLon converts a geographic coordinate into a pixel position
+// within a renderer of the given size, using the same slippy-map tile
+// maths as draw. The second return value is false if the coordinate has
+// panned more than one tile outside the current viewport.
+func (m *Map) pixelForLatLon(lat, lon float64, w, h int) (fyne.Position, bool) {
+	tileSize, _ := m.tileGeometry()
+	midTileX, midTileY := m.viewportOrigin(w, h)
+	mx, my := m.centerTile()
+
+	tx, ty := lonLatToTileXY(lon, lat, m.zoom)
+
+	px := float32(midTileX) + float32(tx-mx)*float32(tileSize)
+	py := float32(midTileY) + float32(ty-my)*float32(tileSize)
+
+	if px < -float32(tileSize) || py < -float32(tileSize) || px > float32(w+tileSize) || py > float32(h+tileSize) {
+		return fyne.Position{}, false
+	}
+	return fyne.NewPos(px, py), true
+}
+
+// lonLatToTileXY converts a geographic coordinate into fractional slippy
+// map tile coordinates at the given zoom level.
+func lonLatToTileXY(lon, lat float64, zoom int) (x, y float64) {
+	n := math.Exp2(float64(zoom))
+	x = (lon + 180) / 360 * n
+
+	latRad := lat * math.Pi / 180
+	y = (1 - math.Log(math.Tan(latRad)+1/math.Cos(latRad))/math.Pi) / 2 * n
+	return x, y
+}
+
+// tileXYToLonLat is the inverse of lonLatToTileXY.
+func tileXYToLonLat(x, y float64, zoom int) (lat, lon float64) {
+	n := math.Exp2(float64(zoom))
+	lon = x/n*360 - 180
+
+	latRad := math.Atan(math.Sinh(math.Pi * (1 - 2*y/n)))
+	lat = latRad * 180 / math.Pi
+	return lat, lon
+}
+
+// mapMarkerIcon is the tappable, hoverable canvas representation of a
+// Marker overlaid on the map.
+type mapMarkerIcon struct {
+	widget.BaseWidget
+
+	marker  *Marker
+	tooltip *widget.PopUp
+}
+
+func newMapMarkerIcon(marker *Marker) *mapMarkerIcon {
+	i := &mapMarkerIcon{marker: marker}
+	i.ExtendBaseWidget(i)
+	return i
+}
+
+func (i *mapMarkerIcon) CreateRenderer() fyne.WidgetRenderer {
+	res := i.marker.Icon
+	if res == nil {
+		res = theme.NavigateNextIcon()
+	}
+
+	img := canvas.NewImageFromResource(res)
+	img.FillMode = canvas.ImageFillContain
+	img.SetMinSize(fyne.NewSize(24, 24))
+	return widget.NewSimpleRenderer(img)
+}
+
+func (i *mapMarkerIcon) MinSize() fyne.Size {
+	return fyne.NewSize(24, 24)
+}
+
+func (i *mapMarkerIcon) Tapped(_ *fyne.PointEvent) {
+	if i.marker.OnTapped != nil {
+		i.marker.OnTapped()
+	}
+}
+
+func (i *mapMarkerIcon) MouseIn(_ *desktop.MouseEvent) {
+	if i.marker.Tooltip == "" {
+		return
+	}
+
+	c := fyne.CurrentApp().Driver().CanvasForObject(i)
+	if c == nil {
+		return
+	}
+
+	i.tooltip = widget.NewPopUp(widget.NewLabel(i.marker.Tooltip), c)
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(i)
+	i.tooltip.ShowAtPosition(pos.Add(fyne.NewPos(0, i.Size().Height)))
+}
+
+func (i *mapMarkerIcon) MouseMoved(_ *desktop.MouseEvent) {
+}
+
+func (i *mapMarkerIcon) MouseOut() {
+	if i.tooltip == nil {
+		return
 	}
 
+	i.tooltip.Hide()
+	i.tooltip = nil
+}
+
+func (m *Map) draw(w, h int) image.Image {
+	// TODO use retina tiles once OSM supports it in their server (text scaling issues)...
+	tileSize, scale := m.tileGeometry()
+
 	if m.w != w || m.h != h {
 		m.pixels = image.NewNRGBA(image.Rect(0, 0, w, h))
 	}
 
-	midTileX := (w - tileSize*2) / 2
-	midTileY := (h - tileSize*2) / 2
-	if m.zoom == 0 {
-		midTileX += tileSize / 2
-		midTileY += tileSize / 2
-	}
+	midTileX, midTileY := m.viewportOrigin(w, h)
+	mx, my := m.centerTile()
 
 	count := 1 << m.zoom
-	mx := m.x + int(float32(count)/2-0.5)
-	my := m.y + int(float32(count)/2-0.5)
-	firstTileX := mx - int(math.Ceil(float64(midTileX)/float64(tileSize)))
-	firstTileY := my - int(math.Ceil(float64(midTileY)/float64(tileSize)))
-
-	for x := firstTileX; (x-firstTileX)*tileSize <= w+tileSize; x++ {
-		for y := firstTileY; (y-firstTileY)*tileSize <= h+tileSize; y++ {
-			if x < 0 || y < 0 || x >= int(count) || y >= int(count) {
+	firstTileX := int(math.Floor(mx)) - int(math.Ceil(float64(midTileX)/float64(tileSize)))
+	firstTileY := int(math.Floor(my)) - int(math.Ceil(float64(midTileY)/float64(tileSize)))
+
+	for x := firstTileX; (float64(x)-mx)*float64(tileSize) <= float64(w+tileSize); x++ {
+		for y := firstTileY; (float64(y)-my)*float64(tileSize) <= float64(h+tileSize); y++ {
+			if x < 0 || y < 0 || x >= count || y >= count {
 				continue
 			}
 
-			src, err := getTile(x, y, m.zoom, m.cl)
+			src, err := getTile(m.source, x, y, m.zoom, m.cl)
 			if err != nil {
 				fyne.LogError("tile fetch error", err)
 				continue
 			}
 
-			pos := image.Pt(midTileX+(x-mx)*tileSize,
-				midTileY+(y-my)*tileSize)
+			pos := image.Pt(midTileX+int(math.Round((float64(x)-mx)*float64(tileSize))),
+				midTileY+int(math.Round((float64(y)-my)*float64(tileSize))))
 			scaled := src
 			if scale > 1 {
 				scaled = resize.Resize(uint(tileSize), uint(tileSize), src, resize.Lanczos2)
@@ -146,5 +593,14 @@ func (m *Map) draw(w, h int) image.Image {
 		}
 	}
 
+	if len(m.effects) > 0 {
+		t := time.Since(m.effectStart)
+		proj := mapProjection{m: m, w: w, h: h}
+		bounds := image.Rect(0, 0, w, h)
+		for _, layer := range m.effects {
+			layer.Render(m.pixels, bounds, t, proj)
+		}
+	}
+
 	return m.pixels
-}
\ No newline at end of file
+}
@@ -0,0 +1,214 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// Projection translates geographic coordinates into pixel positions for
+// the viewport an EffectLayer is rendering into.
+type Projection interface {
+	// PixelForLatLon returns the pixel position of the given coordinate,
+	// and false if it currently falls more than one tile outside the
+	// viewport.
+	PixelForLatLon(lat, lon float64) (fyne.Position, bool)
+}
+
+// mapProjection adapts a Map's slippy-map maths to the Projection
+// interface for a renderer of a fixed size.
+type mapProjection struct {
+	m    *Map
+	w, h int
+}
+
+func (p mapProjection) PixelForLatLon(lat, lon float64) (fyne.Position, bool) {
+	return p.m.pixelForLatLon(lat, lon, p.w, p.h)
+}
+
+// EffectLayer is a time-based procedural renderer composited over a
+// Map's tile raster on every animation frame, useful for things like a
+// live "you are here" pulse, a weather radar sweep, or highlighting
+// search results.
+type EffectLayer interface {
+	// Render draws into dst within bounds, at elapsed time t since the
+	// map's effect animation started (when the first of its current
+	// layers was added), using proj to translate geographic coordinates
+	// into pixel positions.
+	Render(dst *image.NRGBA, bounds image.Rectangle, t time.Duration, proj Projection)
+}
+
+// blendOver alpha-composites c onto dst at (x, y), leaving dst
+// untouched if the position falls outside its bounds.
+func blendOver(dst *image.NRGBA, x, y int, c color.NRGBA) {
+	if c.A == 0 || !(image.Pt(x, y).In(dst.Rect)) {
+		return
+	}
+	if c.A == 0xff {
+		dst.SetNRGBA(x, y, c)
+		return
+	}
+
+	bg := dst.NRGBAAt(x, y)
+	a := float64(c.A) / 0xff
+	mix := func(fg, bg uint8) uint8 {
+		return uint8(float64(fg)*a + float64(bg)*(1-a))
+	}
+	dst.SetNRGBA(x, y, color.NRGBA{R: mix(c.R, bg.R), G: mix(c.G, bg.G), B: mix(c.B, bg.B), A: 0xff})
+}
+
+// PlasmaEffect is a procedural plasma shader EffectLayer, useful as a
+// decorative overlay or for highlighting a region of interest.
+type PlasmaEffect struct {
+	// Palette maps a value in [0, 1) to a color. A default palette that
+	// cycles through the full range of hues is used if nil.
+	Palette func(v float64) color.NRGBA
+}
+
+// Render implements EffectLayer.
+func (p *PlasmaEffect) Render(dst *image.NRGBA, bounds image.Rectangle, t time.Duration, _ Projection) {
+	palette := p.Palette
+	if palette == nil {
+		palette = defaultPlasmaPalette
+	}
+
+	phase := t.Seconds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			fx, fy := float64(x), float64(y)
+			v := math.Sin(fx/16+phase) + math.Sin(fy/16+phase) +
+				math.Sin((fx+fy)/16+phase) + math.Sin(math.Sqrt(fx*fx+fy*fy)/8+phase)
+			blendOver(dst, x, y, palette((v+4)/8))
+		}
+	}
+}
+
+func defaultPlasmaPalette(v float64) color.NRGBA {
+	v = math.Mod(v, 1)
+	if v < 0 {
+		v++
+	}
+	return color.NRGBA{
+		R: uint8(128 + 127*math.Sin(2*math.Pi*v)),
+		G: uint8(128 + 127*math.Sin(2*math.Pi*v+2.094)),
+		B: uint8(128 + 127*math.Sin(2*math.Pi*v+4.188)),
+		A: 90,
+	}
+}
+
+// RadarSweepEffect renders a rotating radar sweep centred on a
+// geographic coordinate, useful for weather radar overlays.
+type RadarSweepEffect struct {
+	Lat, Lon float64
+	Color    color.NRGBA
+
+	Radius float32       // sweep radius in pixels, defaults to 120
+	Period time.Duration // time for one full rotation, defaults to 4s
+}
+
+// Render implements EffectLayer.
+func (r *RadarSweepEffect) Render(dst *image.NRGBA, bounds image.Rectangle, t time.Duration, proj Projection) {
+	center, visible := proj.PixelForLatLon(r.Lat, r.Lon)
+	if !visible {
+		return
+	}
+
+	period := r.Period
+	if period <= 0 {
+		period = 4 * time.Second
+	}
+	radius := r.Radius
+	if radius <= 0 {
+		radius = 120
+	}
+
+	const wedge = math.Pi / 3
+	angle := 2 * math.Pi * (float64(t%period) / float64(period))
+	cx, cy := float64(center.X), float64(center.Y)
+
+	minX, maxX := clampRange(int(cx-float64(radius)), int(cx+float64(radius)), bounds.Min.X, bounds.Max.X)
+	minY, maxY := clampRange(int(cy-float64(radius)), int(cy+float64(radius)), bounds.Min.Y, bounds.Max.Y)
+
+	for y := minY; y < maxY; y++ {
+		for x := minX; x < maxX; x++ {
+			dx, dy := float64(x)-cx, float64(y)-cy
+			dist := math.Hypot(dx, dy)
+			if dist > float64(radius) {
+				continue
+			}
+
+			diff := math.Mod(angle-math.Atan2(dy, dx)+2*math.Pi, 2*math.Pi)
+			if diff > wedge {
+				continue
+			}
+
+			c := r.Color
+			c.A = uint8(float64(c.A) * (1 - diff/wedge) * (1 - dist/float64(radius)))
+			blendOver(dst, x, y, c)
+		}
+	}
+}
+
+// PulseEffect renders a pulsing ring that grows outward from and fades
+// around a geographic coordinate, useful for a live "you are here" GPS
+// indicator.
+type PulseEffect struct {
+	Lat, Lon float64
+	Color    color.NRGBA
+
+	MaxRadius float32       // radius the ring grows to, defaults to 40
+	Period    time.Duration // time for one pulse cycle, defaults to 2s
+}
+
+// Render implements EffectLayer.
+func (p *PulseEffect) Render(dst *image.NRGBA, bounds image.Rectangle, t time.Duration, proj Projection) {
+	center, visible := proj.PixelForLatLon(p.Lat, p.Lon)
+	if !visible {
+		return
+	}
+
+	period := p.Period
+	if period <= 0 {
+		period = 2 * time.Second
+	}
+	maxRadius := p.MaxRadius
+	if maxRadius <= 0 {
+		maxRadius = 40
+	}
+
+	const thickness = 2.0
+	phase := float64(t%period) / float64(period)
+	radius := float64(maxRadius) * phase
+	fade := 1 - phase
+
+	cx, cy := float64(center.X), float64(center.Y)
+	minX, maxX := clampRange(int(cx-float64(maxRadius)-thickness), int(cx+float64(maxRadius)+thickness), bounds.Min.X, bounds.Max.X)
+	minY, maxY := clampRange(int(cy-float64(maxRadius)-thickness), int(cy+float64(maxRadius)+thickness), bounds.Min.Y, bounds.Max.Y)
+
+	for y := minY; y < maxY; y++ {
+		for x := minX; x < maxX; x++ {
+			dist := math.Hypot(float64(x)-cx, float64(y)-cy)
+			if dist < radius-thickness || dist > radius+thickness {
+				continue
+			}
+
+			c := p.Color
+			c.A = uint8(float64(c.A) * fade)
+			blendOver(dst, x, y, c)
+		}
+	}
+}
+
+// clampRange clamps [lo, hi) to fall within [min, max).
+func clampRange(lo, hi, min, max int) (int, int) {
+	if lo < min {
+		lo = min
+	}
+	if hi > max {
+		hi = max
+	}
+	return lo, hi
+}
@@ -0,0 +1,61 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestBlendOverAlpha(t *testing.T) {
+	bg := color.NRGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xff}
+
+	t.Run("opaque overwrites the background", func(t *testing.T) {
+		dst := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+		dst.SetNRGBA(0, 0, bg)
+
+		blendOver(dst, 0, 0, color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff})
+
+		got := dst.NRGBAAt(0, 0)
+		if got != (color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}) {
+			t.Errorf("blendOver with A=0xff = %v, want opaque red", got)
+		}
+	})
+
+	t.Run("fully transparent leaves the background untouched", func(t *testing.T) {
+		dst := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+		dst.SetNRGBA(0, 0, bg)
+
+		blendOver(dst, 0, 0, color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0x00})
+
+		if got := dst.NRGBAAt(0, 0); got != bg {
+			t.Errorf("blendOver with A=0 = %v, want unchanged background %v", got, bg)
+		}
+	})
+
+	t.Run("half alpha mixes toward the foreground", func(t *testing.T) {
+		dst := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+		dst.SetNRGBA(0, 0, color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff})
+
+		blendOver(dst, 0, 0, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x80})
+
+		got := dst.NRGBAAt(0, 0)
+		if got.A != 0xff {
+			t.Errorf("blendOver result should stay fully opaque, got A=%d", got.A)
+		}
+		// 0x80/0xff alpha over black should land roughly mid-grey.
+		if got.R < 0x70 || got.R > 0x90 {
+			t.Errorf("blendOver with A=0x80 over black = %v, want R roughly 0x80", got)
+		}
+	})
+
+	t.Run("out of bounds is a no-op", func(t *testing.T) {
+		dst := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+		dst.SetNRGBA(0, 0, bg)
+
+		blendOver(dst, 5, 5, color.NRGBA{R: 0xff, A: 0xff})
+
+		if got := dst.NRGBAAt(0, 0); got != bg {
+			t.Errorf("out-of-bounds blendOver modified dst: %v", got)
+		}
+	})
+}
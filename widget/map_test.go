@@ -0,0 +1,68 @@
+package widget
+
+import (
+	"math"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestMapSetCenterCentersOnViewport(t *testing.T) {
+	test.NewApp()
+
+	cases := []struct {
+		name     string
+		zoom     int
+		lat, lon float64
+		w, h     int
+	}{
+		{"zoom 0, world centre", 0, 0, 0, 800, 800},
+		{"zoom 2, world centre", 2, 0, 0, 800, 800},
+		{"zoom 4, off-centre coordinate", 4, 48.8566, 2.3522, 640, 480},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := &Map{source: OSMTileSource}
+			m.zoom = c.zoom
+			m.SetCenter(c.lat, c.lon)
+
+			pos, visible := m.pixelForLatLon(c.lat, c.lon, c.w, c.h)
+			if !visible {
+				t.Fatalf("centred coordinate reported as outside the viewport")
+			}
+
+			wantX, wantY := float32(c.w)/2, float32(c.h)/2
+			if math.Abs(float64(pos.X-wantX)) > 0.5 || math.Abs(float64(pos.Y-wantY)) > 0.5 {
+				t.Errorf("SetCenter(%v, %v) then pixelForLatLon = %v, want ~%v",
+					c.lat, c.lon, pos, fyne.NewPos(wantX, wantY))
+			}
+		})
+	}
+}
+
+func TestLonLatTileXYRoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		lat, lon float64
+		zoom     int
+	}{
+		{"equator/prime meridian", 0, 0, 0},
+		{"Paris, zoom 10", 48.8566, 2.3522, 10},
+		{"Sydney, zoom 14", -33.8688, 151.2093, 14},
+		{"near north pole, zoom 5", 85.0, -179.9, 5},
+		{"date line, zoom 3", 10.0, 179.9, 3},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			x, y := lonLatToTileXY(c.lon, c.lat, c.zoom)
+			lat, lon := tileXYToLonLat(x, y, c.zoom)
+
+			if math.Abs(lat-c.lat) > 1e-6 || math.Abs(lon-c.lon) > 1e-6 {
+				t.Errorf("round-trip (%v, %v) at zoom %d = (%v, %v)", c.lat, c.lon, c.zoom, lat, lon)
+			}
+		})
+	}
+}
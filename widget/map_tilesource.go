@@ -0,0 +1,301 @@
+package widget
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tileUserAgent identifies this library to tile servers. OSM's tile
+// usage policy rejects requests without a descriptive User-Agent, and
+// other XYZ servers commonly apply the same rule.
+const tileUserAgent = "fyne-x-map/1.0 (+https://github.com/fyne-io/fyne-x)"
+
+// TileSource describes a slippy-map raster tile provider.
+type TileSource interface {
+	// URLTemplate returns the tile URL, with "{z}", "{x}" and "{y}"
+	// placeholders for the zoom level and tile coordinates.
+	URLTemplate() string
+	// Attribution returns the copyright notice to display over the map.
+	Attribution() string
+	// MaxZoom returns the highest zoom level this source supports.
+	MaxZoom() int
+	// TileSize returns the native pixel size of a single tile.
+	TileSize() int
+	// CacheKey returns a short, stable identifier for this source, used
+	// to namespace its tiles in the on-disk cache. Unlike URLTemplate it
+	// must not change if the source starts serving from a different
+	// host or subdomain.
+	CacheKey() string
+}
+
+// xyzSource is a TileSource fully described by a URL template.
+type xyzSource struct {
+	cacheKey    string
+	url         string
+	attribution string
+	maxZoom     int
+	tileSize    int
+}
+
+func (s *xyzSource) URLTemplate() string { return s.url }
+func (s *xyzSource) Attribution() string { return s.attribution }
+func (s *xyzSource) MaxZoom() int        { return s.maxZoom }
+func (s *xyzSource) TileSize() int       { return s.tileSize }
+func (s *xyzSource) CacheKey() string    { return s.cacheKey }
+
+// NewXYZTileSource creates a TileSource from a generic tile URL template
+// containing "{z}", "{x}" and "{y}" placeholders, for servers without a
+// built-in TileSource. cacheKey is a short, stable identifier for the
+// source (e.g. "my-company-tiles") used to namespace its tiles on disk;
+// it should stay the same across subdomain or host changes to the
+// underlying urlTemplate.
+func NewXYZTileSource(cacheKey, urlTemplate, attribution string, maxZoom int) TileSource {
+	return &xyzSource{cacheKey: cacheKey, url: urlTemplate, attribution: attribution, maxZoom: maxZoom, tileSize: tileSize}
+}
+
+// OSMTileSource is the default TileSource, serving standard raster tiles
+// from the OpenStreetMap project.
+var OSMTileSource TileSource = &xyzSource{
+	cacheKey:    "osm",
+	url:         "https://tile.openstreetmap.org/{z}/{x}/{y}.png",
+	attribution: "OpenStreetMap",
+	maxZoom:     19,
+	tileSize:    tileSize,
+}
+
+// OpenTopoMapTileSource renders topographic tiles from OpenTopoMap.
+var OpenTopoMapTileSource TileSource = &xyzSource{
+	cacheKey:    "opentopomap",
+	url:         "https://a.tile.opentopomap.org/{z}/{x}/{y}.png",
+	attribution: "OpenTopoMap (CC-BY-SA)",
+	maxZoom:     17,
+	tileSize:    tileSize,
+}
+
+// StamenTonerTileSource renders high-contrast black and white tiles from
+// Stamen Design.
+var StamenTonerTileSource TileSource = &xyzSource{
+	cacheKey:    "stamen-toner",
+	url:         "https://stamen-tiles.a.ssl.fastly.net/toner/{z}/{x}/{y}.png",
+	attribution: "Stamen Design (CC-BY 3.0)",
+	maxZoom:     20,
+	tileSize:    tileSize,
+}
+
+// tileKey identifies a single cached tile.
+type tileKey struct {
+	source  string
+	z, x, y int
+}
+
+type tileCacheEntry struct {
+	img     image.Image
+	expires time.Time
+}
+
+// tileMemCache is an in-memory LRU cache of decoded tiles, shared by all
+// Map widgets and keyed by source, zoom and tile coordinate.
+type tileMemCache struct {
+	mu       sync.Mutex
+	order    []tileKey
+	entries  map[tileKey]*tileCacheEntry
+	capacity int
+}
+
+var tiles = &tileMemCache{entries: map[tileKey]*tileCacheEntry{}, capacity: 512}
+
+func (c *tileMemCache) get(key tileKey) (image.Image, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.touch(key)
+	return e.img, true
+}
+
+func (c *tileMemCache) put(key tileKey, img image.Image, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok {
+		c.order = append(c.order, key)
+		if len(c.order) > c.capacity {
+			oldest := c.order[0]
+			c.order = c.order[1:]
+			delete(c.entries, oldest)
+		}
+	} else {
+		c.touch(key)
+	}
+	c.entries[key] = &tileCacheEntry{img: img, expires: expires}
+}
+
+// touch moves key to the most-recently-used end of the eviction order.
+// Caller must hold c.mu.
+func (c *tileMemCache) touch(key tileKey) {
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			c.order = append(c.order, key)
+			return
+		}
+	}
+}
+
+// getTile fetches a single tile from source at the given zoom/x/y,
+// serving it from the in-memory or on-disk cache when available so that
+// panning over a previously viewed region is instant and works offline.
+func getTile(source TileSource, x, y, zoom int, cl *http.Client) (image.Image, error) {
+	key := tileKey{source: source.CacheKey(), z: zoom, x: x, y: y}
+	if img, ok := tiles.get(key); ok {
+		return img, nil
+	}
+
+	if img, expires, err := readDiskTile(source.CacheKey(), zoom, x, y); err == nil {
+		tiles.put(key, img, expires)
+		return img, nil
+	}
+
+	tileURL := expandTileURL(source.URLTemplate(), zoom, x, y)
+	req, err := http.NewRequest(http.MethodGet, tileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", tileUserAgent)
+
+	resp, err := cl.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching tile %s: server returned %s", tileURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	expires := tileExpiry(resp.Header)
+	tiles.put(key, img, expires)
+	writeDiskTile(source.CacheKey(), zoom, x, y, data, expires)
+
+	return img, nil
+}
+
+func expandTileURL(urlTemplate string, zoom, x, y int) string {
+	r := strings.NewReplacer(
+		"{z}", strconv.Itoa(zoom),
+		"{x}", strconv.Itoa(x),
+		"{y}", strconv.Itoa(y))
+	return r.Replace(urlTemplate)
+}
+
+// tileExpiry determines how long a fetched tile may be served from the
+// disk cache before it needs to be re-fetched, honouring the response's
+// Cache-Control/Expires headers and falling back to a week otherwise.
+func tileExpiry(h http.Header) time.Time {
+	if v := h.Get("Cache-Control"); v != "" {
+		for _, part := range strings.Split(v, ",") {
+			part = strings.TrimSpace(part)
+			if strings.HasPrefix(part, "max-age=") {
+				if secs, err := strconv.Atoi(strings.TrimPrefix(part, "max-age=")); err == nil {
+					return time.Now().Add(time.Duration(secs) * time.Second)
+				}
+			}
+		}
+	}
+	if v := h.Get("Expires"); v != "" {
+		if t, err := http.ParseTime(v); err == nil {
+			return t
+		}
+	}
+	return time.Now().Add(7 * 24 * time.Hour)
+}
+
+// diskTileDir returns os.UserCacheDir()/fyne-x/tiles/<cacheKey>/<z>/<x>,
+// the directory holding the <y>.png tile file and its sidecar expiry.
+// cacheKey is a TileSource's CacheKey(), not its URLTemplate(), so the
+// cache tree stays stable across host/subdomain changes to a source and
+// never contains raw URL template syntax.
+func diskTileDir(cacheKey string, z, x int) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "fyne-x", "tiles", sanitizeSourceName(cacheKey),
+		strconv.Itoa(z), strconv.Itoa(x)), nil
+}
+
+// sanitizeSourceName defends against a CacheKey containing path
+// separators or other characters that don't belong in a directory name.
+func sanitizeSourceName(name string) string {
+	return strings.NewReplacer("://", "_", "/", "_", ":", "_", " ", "_").Replace(name)
+}
+
+func readDiskTile(cacheKey string, z, x, y int) (image.Image, time.Time, error) {
+	dir, err := diskTileDir(cacheKey, z, x)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	path := filepath.Join(dir, strconv.Itoa(y)+".png")
+
+	expires := time.Now().Add(7 * 24 * time.Hour)
+	if data, err := os.ReadFile(path + ".expires"); err == nil {
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data))); err == nil {
+			expires = t
+		}
+	}
+	if time.Now().After(expires) {
+		return nil, time.Time{}, fmt.Errorf("cached tile %s has expired", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return img, expires, nil
+}
+
+func writeDiskTile(cacheKey string, z, x, y int, data []byte, expires time.Time) {
+	dir, err := diskTileDir(cacheKey, z, x)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+
+	path := filepath.Join(dir, strconv.Itoa(y)+".png")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return
+	}
+	_ = os.WriteFile(path+".expires", []byte(expires.Format(time.RFC3339)), 0o644)
+}
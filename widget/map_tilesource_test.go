@@ -0,0 +1,94 @@
+package widget
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTileMemCacheLRUEviction(t *testing.T) {
+	c := &tileMemCache{entries: map[tileKey]*tileCacheEntry{}, capacity: 2}
+
+	k1 := tileKey{source: "s", z: 1, x: 0, y: 0}
+	k2 := tileKey{source: "s", z: 1, x: 0, y: 1}
+	k3 := tileKey{source: "s", z: 1, x: 0, y: 2}
+
+	c.put(k1, nil, time.Time{})
+	c.put(k2, nil, time.Time{})
+	c.put(k3, nil, time.Time{})
+
+	if _, ok := c.get(k1); ok {
+		t.Errorf("k1 should have been evicted once capacity was exceeded")
+	}
+	if _, ok := c.get(k2); !ok {
+		t.Errorf("k2 should still be cached")
+	}
+	if _, ok := c.get(k3); !ok {
+		t.Errorf("k3 should still be cached")
+	}
+}
+
+func TestTileMemCacheTouchOrdering(t *testing.T) {
+	c := &tileMemCache{entries: map[tileKey]*tileCacheEntry{}, capacity: 2}
+
+	k1 := tileKey{source: "s", z: 1, x: 0, y: 0}
+	k2 := tileKey{source: "s", z: 1, x: 0, y: 1}
+	k3 := tileKey{source: "s", z: 1, x: 0, y: 2}
+
+	c.put(k1, nil, time.Time{})
+	c.put(k2, nil, time.Time{})
+
+	// Touch k1 so it is no longer the least recently used entry.
+	if _, ok := c.get(k1); !ok {
+		t.Fatalf("k1 should be cached before the touch")
+	}
+
+	c.put(k3, nil, time.Time{})
+
+	if _, ok := c.get(k1); !ok {
+		t.Errorf("k1 should have survived eviction after being touched")
+	}
+	if _, ok := c.get(k2); ok {
+		t.Errorf("k2 should have been evicted as the least recently used entry")
+	}
+}
+
+func TestTileExpiry(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Cache-Control max-age", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("Cache-Control", "public, max-age=3600")
+		expires := tileExpiry(h)
+		if d := expires.Sub(now); d < 59*time.Minute || d > 61*time.Minute {
+			t.Errorf("expected expiry ~1h from now, got %v", d)
+		}
+	})
+
+	t.Run("Expires header", func(t *testing.T) {
+		h := http.Header{}
+		want := now.Add(2 * time.Hour).UTC().Truncate(time.Second)
+		h.Set("Expires", want.Format(http.TimeFormat))
+		expires := tileExpiry(h)
+		if !expires.Equal(want) {
+			t.Errorf("tileExpiry(Expires: %v) = %v, want %v", want, expires, want)
+		}
+	})
+
+	t.Run("no headers falls back to a week", func(t *testing.T) {
+		expires := tileExpiry(http.Header{})
+		if d := expires.Sub(now); d < 6*24*time.Hour || d > 8*24*time.Hour {
+			t.Errorf("expected a ~7 day fallback expiry, got %v", d)
+		}
+	})
+
+	t.Run("Cache-Control takes priority over Expires", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("Cache-Control", "max-age=60")
+		h.Set("Expires", now.Add(24*time.Hour).Format(http.TimeFormat))
+		expires := tileExpiry(h)
+		if d := expires.Sub(now); d < 0 || d > 2*time.Minute {
+			t.Errorf("expected Cache-Control's max-age to take priority, got expiry %v from now", d)
+		}
+	})
+}